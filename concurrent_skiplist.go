@@ -0,0 +1,314 @@
+package gocodebase
+
+import (
+	"cmp"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// nodeKind 标记 csNode 是普通节点还是头/尾哨兵节点，哨兵节点没有真实的 key，
+// 比较时分别视为"最小"和"最大"。
+type nodeKind int
+
+const (
+	nodeNormal nodeKind = iota
+	nodeHead
+	nodeTail
+)
+
+// csNode 是 ConcurrentSkipList 的节点：next 用原子指针存储，支持无锁读；
+// marked/fullyLinked 用于实现 Herlihy-Shavit 风格的懒同步插入/删除；
+// mu 只在写路径上锁住该节点作为"前驱"时使用。
+//
+// value 也用原子指针存储而不是裸字段：Get 完全无锁地读它，如果它是一个普通字段，
+// 无锁读和 Set 在找到已存在节点时的写就会在同一块内存上分别用"无同步"和"mu 加锁"
+// 两套不同的同步机制，构成数据竞争。统一成原子指针后两边都走 atomic 的 happens-before。
+type csNode[K any, V any] struct {
+	key      K
+	value    atomic.Pointer[V]
+	kind     nodeKind
+	topLevel int
+	next     []atomic.Pointer[csNode[K, V]]
+
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+	mu          sync.Mutex
+}
+
+// ConcurrentSkipList 是 SkipList 的细粒度并发版本：读路径完全无锁（只做原子读），
+// 写路径只锁住被拼接的那几个前驱节点，不再用一把 RWMutex 串行化所有读写。
+type ConcurrentSkipList[K any, V any] struct {
+	head, tail *csNode[K, V]
+	maxLevel   int
+	less       func(a, b K) bool
+
+	length int64 // 原子读写，见 Len
+
+	randMu      sync.Mutex // 只保护 PRNG 与概率表，不参与节点拼接的临界区
+	randSource  rand.Source
+	probability float64
+	probTable   []float64
+}
+
+// NewConcurrentSkipList 新建并发跳转表，key 为 float64，与 SkipList 对应。
+func NewConcurrentSkipList() *ConcurrentSkipList[float64, interface{}] {
+	return NewConcurrentOrdered[float64, interface{}]()
+}
+
+// NewConcurrentOrdered 新建一个 key 为 cmp.Ordered 类型的并发跳转表。
+func NewConcurrentOrdered[K cmp.Ordered, V any]() *ConcurrentSkipList[K, V] {
+	return NewConcurrentSkipListFunc[K, V](func(a, b K) bool { return a < b })
+}
+
+// NewConcurrentSkipListFunc 使用自定义比较器 less 新建并发跳转表。
+func NewConcurrentSkipListFunc[K any, V any](less func(a, b K) bool) *ConcurrentSkipList[K, V] {
+	maxLevel := DefaultMaxLevel
+
+	head := &csNode[K, V]{kind: nodeHead, topLevel: maxLevel - 1, next: make([]atomic.Pointer[csNode[K, V]], maxLevel)}
+	tail := &csNode[K, V]{kind: nodeTail, topLevel: maxLevel - 1}
+	head.fullyLinked.Store(true)
+	tail.fullyLinked.Store(true)
+	for i := range head.next {
+		head.next[i].Store(tail)
+	}
+
+	return &ConcurrentSkipList[K, V]{
+		head:     head,
+		tail:     tail,
+		maxLevel: maxLevel,
+		less:     less,
+		// 种子取当前时间，避免不同进程产出完全相同形状的跳表；
+		// 并发版本没有提供指定种子或 rand.Source 的选项。
+		randSource:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		probability: DefaultProbability,
+		probTable:   ProbabilityTable(DefaultProbability, maxLevel),
+	}
+}
+
+// Len 返回当前元素个数。
+func (list *ConcurrentSkipList[K, V]) Len() int {
+	return int(atomic.LoadInt64(&list.length))
+}
+
+// SetProbability 设置新的概率并原子地刷新概率表；newProbability 必须落在 (0, 1) 内。
+// 用 randMu 而不是整表的锁来保护，因为 probTable 只被 randLevel 读取。
+func (list *ConcurrentSkipList[K, V]) SetProbability(newProbability float64) {
+	if newProbability <= 0 || newProbability >= 1 {
+		panic("invalid probability")
+	}
+
+	list.randMu.Lock()
+	defer list.randMu.Unlock()
+
+	list.probability = newProbability
+	list.probTable = ProbabilityTable(newProbability, list.maxLevel)
+}
+
+// randLevel 借用与 SkipList 相同的概率表算法；PRNG 状态用 randMu 保护，
+// 因为它不属于任何一个节点，无法通过节点锁来保证互斥。
+func (list *ConcurrentSkipList[K, V]) randLevel() int {
+	list.randMu.Lock()
+	defer list.randMu.Unlock()
+
+	r := float64(list.randSource.Int63()) / (1 << 63)
+	level := 1
+	for level < list.maxLevel && r < list.probTable[level] {
+		level++
+	}
+	return level
+}
+
+// nodeLessThanKey 报告 n 是否应该排在 key 之前；头节点永远在最前，尾节点永远在最后。
+func (list *ConcurrentSkipList[K, V]) nodeLessThanKey(n *csNode[K, V], key K) bool {
+	switch n.kind {
+	case nodeHead:
+		return true
+	case nodeTail:
+		return false
+	default:
+		return list.less(n.key, key)
+	}
+}
+
+// equalKey 报告普通节点 n 的 key 是否与 key 相等。
+func (list *ConcurrentSkipList[K, V]) equalKey(n *csNode[K, V], key K) bool {
+	return n.kind == nodeNormal && !list.less(n.key, key) && !list.less(key, n.key)
+}
+
+// find 自顶层向下查找 key，把每一层的前驱/后继分别记录到 preds/succs，
+// 返回"找到相等节点"的最高层号，找不到返回 -1。全程只做原子读，可与写者并发。
+func (list *ConcurrentSkipList[K, V]) find(key K, preds, succs []*csNode[K, V]) int {
+	foundLevel := -1
+	pred := list.head
+	for level := list.maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for list.nodeLessThanKey(curr, key) {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if foundLevel == -1 && list.equalKey(curr, key) {
+			foundLevel = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return foundLevel
+}
+
+// Get 无锁读取 key 对应的值；只有完全拼接好(fullyLinked)且未被标记删除(marked)的
+// 节点才会被认为可见，这保证了并发的 Set/Remove 不会让 Get 看到半成品节点。
+func (list *ConcurrentSkipList[K, V]) Get(key K) (V, bool) {
+	var zero V
+
+	pred := list.head
+	var curr *csNode[K, V]
+	for level := list.maxLevel - 1; level >= 0; level-- {
+		curr = pred.next[level].Load()
+		for list.nodeLessThanKey(curr, key) {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+	}
+
+	if list.equalKey(curr, key) && curr.fullyLinked.Load() && !curr.marked.Load() {
+		if v := curr.value.Load(); v != nil {
+			return *v, true
+		}
+	}
+	return zero, false
+}
+
+// Set 插入或更新 key 对应的值。遵循经典的"乐观查找 + 锁前驱校验"方案：
+// 先无锁找到每层前驱/后继，只锁住将被改写的前驱节点并校验它们未被标记/未变化，
+// 校验失败则解锁重试；找到已存在节点则直接在其锁保护下更新 value。
+func (list *ConcurrentSkipList[K, V]) Set(key K, value V) {
+	topLevel := list.randLevel() - 1
+	preds := make([]*csNode[K, V], list.maxLevel)
+	succs := make([]*csNode[K, V], list.maxLevel)
+
+	for {
+		lFound := list.find(key, preds, succs)
+		if lFound != -1 {
+			found := succs[lFound]
+			if !found.marked.Load() {
+				for !found.fullyLinked.Load() {
+					runtime.Gosched() // 等待另一个正在插入同一 key 的写者完成拼接
+				}
+				found.mu.Lock()
+				if found.marked.Load() {
+					// 在加锁前的这段时间里被并发 Remove 摘掉了，不能再更新，重新走一遍查找/插入
+					found.mu.Unlock()
+					continue
+				}
+				stored := value
+				found.value.Store(&stored)
+				found.mu.Unlock()
+				return
+			}
+			continue // 找到的节点正在被删除，重试
+		}
+
+		var prevPred *csNode[K, V]
+		valid := true
+		lockedNodes := make([]*csNode[K, V], 0, topLevel+1)
+		for level := 0; valid && level <= topLevel; level++ {
+			pred := preds[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				lockedNodes = append(lockedNodes, pred)
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && !succs[level].marked.Load() && pred.next[level].Load() == succs[level]
+		}
+
+		if !valid {
+			unlockAll(lockedNodes)
+			continue
+		}
+
+		newNode := &csNode[K, V]{
+			key:      key,
+			kind:     nodeNormal,
+			topLevel: topLevel,
+			next:     make([]atomic.Pointer[csNode[K, V]], topLevel+1),
+		}
+		stored := value
+		newNode.value.Store(&stored)
+		for level := 0; level <= topLevel; level++ {
+			newNode.next[level].Store(succs[level])
+			preds[level].next[level].Store(newNode)
+		}
+		newNode.fullyLinked.Store(true)
+		atomic.AddInt64(&list.length, 1)
+
+		unlockAll(lockedNodes)
+		return
+	}
+}
+
+// Remove 删除 key 对应的节点，返回是否真的删除了一个节点。
+// 先把节点标记为 marked（逻辑删除，使其立刻对新的 Get/find 不可见），
+// 再锁住各层前驱把它从链表中物理摘除，与 Set 共用同一套"锁前驱并校验"逻辑。
+func (list *ConcurrentSkipList[K, V]) Remove(key K) bool {
+	var victim *csNode[K, V]
+	isMarked := false
+	topLevel := -1
+	preds := make([]*csNode[K, V], list.maxLevel)
+	succs := make([]*csNode[K, V], list.maxLevel)
+
+	for {
+		lFound := list.find(key, preds, succs)
+		if lFound != -1 {
+			victim = succs[lFound]
+		}
+
+		if !isMarked {
+			if lFound == -1 || victim.marked.Load() {
+				return false
+			}
+			topLevel = victim.topLevel
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		var prevPred *csNode[K, V]
+		valid := true
+		lockedNodes := make([]*csNode[K, V], 0, topLevel+1)
+		for level := 0; valid && level <= topLevel; level++ {
+			pred := preds[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				lockedNodes = append(lockedNodes, pred)
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == succs[level]
+		}
+
+		if !valid {
+			unlockAll(lockedNodes)
+			continue
+		}
+
+		for level := topLevel; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mu.Unlock()
+		unlockAll(lockedNodes)
+		atomic.AddInt64(&list.length, -1)
+		return true
+	}
+}
+
+func unlockAll[K any, V any](nodes []*csNode[K, V]) {
+	for _, n := range nodes {
+		n.mu.Unlock()
+	}
+}
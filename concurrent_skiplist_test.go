@@ -0,0 +1,102 @@
+package gocodebase
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConcurrentSkipListParallelSetGet 先用多个 goroutine 并发 Set 互不相同的 key，
+// 全部完成后再用多个 goroutine 并发 Get，验证每个 key 都能读到写入的值且 Len() 正确。
+// 每个 key 只写一次，Set 与 Get 也不重叠；更新路径上的并发读写由
+// TestConcurrentSkipListSetUpdateRace 覆盖。
+func TestConcurrentSkipListParallelSetGet(t *testing.T) {
+	list := NewConcurrentOrdered[int, int]()
+
+	const n = 200
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			list.Set(i, i*i)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, ok := list.Get(i)
+			if !ok {
+				t.Errorf("Get(%d) missing", i)
+				return
+			}
+			if v != i*i {
+				t.Errorf("Get(%d) = %d, want %d", i, v, i*i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := list.Len(); got != n {
+		t.Fatalf("Len() = %d, want %d", got, n)
+	}
+}
+
+// TestConcurrentSkipListSetUpdateRace 重复 Set 同一个 key 的并发写，同时有并发 Get，
+// 验证更新路径下 value 的读写不会被竞态检测器标记，并且最终值是某次 Set 写入的值。
+func TestConcurrentSkipListSetUpdateRace(t *testing.T) {
+	list := NewConcurrentOrdered[int, int]()
+	list.Set(1, -1)
+
+	const writers = 100
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			list.Set(1, i)
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list.Get(1)
+		}()
+	}
+	wg.Wait()
+
+	v, ok := list.Get(1)
+	if !ok {
+		t.Fatalf("Get(1) missing after concurrent updates")
+	}
+	if v < 0 || v >= writers {
+		t.Fatalf("Get(1) = %d, want a value written by one of the concurrent Set calls", v)
+	}
+}
+
+// TestConcurrentSkipListSetRemoveRace 让同一个 key 反复被并发 Set 和 Remove，
+// 走到 Set 遇到被并发标记删除的节点后重试的路径；验证整个过程不发生 panic 或死锁，
+// 且全部结束后 Len() 非负。
+func TestConcurrentSkipListSetRemoveRace(t *testing.T) {
+	list := NewConcurrentOrdered[int, int]()
+
+	const rounds = 100
+	var wg sync.WaitGroup
+	for i := 0; i < rounds; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			list.Set(2, i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			list.Remove(2)
+		}()
+	}
+	wg.Wait()
+
+	if list.Len() < 0 {
+		t.Fatalf("Len() = %d, must never go negative", list.Len())
+	}
+}
@@ -0,0 +1,91 @@
+package gocodebase
+
+import (
+	"cmp"
+	"math/rand"
+	"time"
+)
+
+// config 收集 NewWithOptions 的可选配置，与 K、V 无关，因此不需要泛型化。
+type config struct {
+	maxLevel    int
+	probability float64
+	seed        int64
+	randSource  rand.Source
+}
+
+// Option 用于配置 NewWithOptions 新建的跳表。
+type Option func(*config)
+
+// WithMaxLevel 设置跳表的最大深度，默认 DefaultMaxLevel。
+func WithMaxLevel(maxLevel int) Option {
+	return func(c *config) {
+		c.maxLevel = maxLevel
+	}
+}
+
+// WithProbability 设置跳表的层级提升概率，默认 DefaultProbability；必须落在 (0, 1) 内。
+func WithProbability(probability float64) Option {
+	return func(c *config) {
+		c.probability = probability
+	}
+}
+
+// WithSeed 设置 PRNG 种子，用于需要可复现层级结构的场景（例如对比两次快照）。
+// 与 WithRandSource 同时使用时，WithRandSource 优先生效。
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// WithRandSource 直接提供一个 rand.Source，覆盖 WithSeed 的默认构造方式。
+func WithRandSource(source rand.Source) Option {
+	return func(c *config) {
+		c.randSource = source
+	}
+}
+
+// NewWithOptions 使用自定义比较器 less 和一组 Option 新建跳表。
+// 默认种子为 time.Now().UnixNano()，因此两个不加选项的进程不会产出形状完全相同的跳表；
+// 如需确定性层级结构（例如对比快照差异），请显式传入 WithSeed 或 WithRandSource。
+func NewWithOptions[K any, V any](less func(a, b K) bool, opts ...Option) *SkipList[K, V] {
+	cfg := &config{
+		maxLevel:    DefaultMaxLevel,
+		probability: DefaultProbability,
+		seed:        time.Now().UnixNano(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.maxLevel < 1 || cfg.maxLevel > DefaultMaxLevel {
+		panic("invalid maxlevel")
+	}
+	if cfg.probability <= 0 || cfg.probability >= 1 {
+		panic("invalid probability")
+	}
+
+	randSource := cfg.randSource
+	if randSource == nil {
+		randSource = rand.New(rand.NewSource(cfg.seed))
+	}
+
+	return &SkipList[K, V]{
+		elementNode: elementNode[K, V]{
+			next: make([]*Element[K, V], cfg.maxLevel),
+			span: make([]int, cfg.maxLevel),
+		},
+		maxLevel:    cfg.maxLevel,
+		randSource:  randSource,
+		seed:        cfg.seed,
+		probability: cfg.probability,
+		probTable:   ProbabilityTable(cfg.probability, cfg.maxLevel),
+		less:        less,
+	}
+}
+
+// NewOrderedWithOptions 是 NewWithOptions 针对 cmp.Ordered key 的便捷封装，使用默认的小于比较。
+func NewOrderedWithOptions[K cmp.Ordered, V any](opts ...Option) *SkipList[K, V] {
+	return NewWithOptions[K, V](func(a, b K) bool { return a < b }, opts...)
+}
@@ -0,0 +1,179 @@
+package gocodebase
+
+import (
+	"encoding/gob"
+	"io"
+	"math/rand"
+)
+
+// snapshotEntry 是快照中单个 key/value 对的落盘格式。
+type snapshotEntry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// snapshot 是 Snapshot/Restore 之间传递的完整落盘格式：
+// level-0 链（按 key 升序）加上重建跳表所需的元信息。
+type snapshot[K any, V any] struct {
+	MaxLevel    int
+	Probability float64
+	Seed        int64
+	Entries     []snapshotEntry[K, V]
+}
+
+// Snapshot 把跳表按 key 升序序列化到 w：maxLevel、probability、PRNG 种子，
+// 以及 gob 编码的 key/value 对。value 如果是接口类型，调用方需要提前用
+// gob.Register 注册其具体类型，这是 encoding/gob 的通用限制。
+func (list *SkipList[K, V]) Snapshot(w io.Writer) error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	snap := snapshot[K, V]{
+		MaxLevel:    list.maxLevel,
+		Probability: list.probability,
+		Seed:        list.seed,
+		Entries:     make([]snapshotEntry[K, V], 0, list.length),
+	}
+	for e := list.next[0]; e != nil; e = e.next[0] {
+		snap.Entries = append(snap.Entries, snapshotEntry[K, V]{Key: e.key, Value: e.value})
+	}
+
+	return gob.NewEncoder(w).Encode(snap)
+}
+
+// Restore 从 r 中读取 Snapshot 写出的数据，重建一个等价的跳表。
+// 由于快照中的条目已经按 key 升序排列，重建走一条跳过逐层查找的快速路径：
+// 直接维护每层的尾指针，在 O(n) 内把条目依次挂到对应层，而不是重新做
+// O(n log n) 的 Set。less 用于在重建后的跳表上继续做比较，必须与序列化前一致。
+func Restore[K any, V any](r io.Reader, less func(a, b K) bool) (*SkipList[K, V], error) {
+	var snap snapshot[K, V]
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, err
+	}
+
+	list := NewWithMaxLevelFunc[K, V](snap.MaxLevel, less)
+	list.probability = snap.Probability
+	list.probTable = ProbabilityTable(snap.Probability, snap.MaxLevel)
+	list.seed = snap.Seed
+	list.randSource = rand.New(rand.NewSource(snap.Seed))
+
+	list.appendAscending(snap.Entries)
+
+	return list, nil
+}
+
+// appendAscending 把已经按 key 升序排好的条目直接追加到跳表尾部：
+// 每层只维护一个尾指针，新节点的层数仍由 randLevel 决定，但不再需要
+// getPrevElementNodes 那样从头查找前驱，从而把重建复杂度降到 O(n)。
+//
+// span 同样在这一趟里顺带算好：levelCount[i] 记录自上次在第 i 层落下尾指针以来
+// 经过了多少个条目，每处理一个条目就先给所有层的计数器加一，轮到某一层真正
+// 落下新的尾指针时，该层计数器的当前值就是旧尾指针到这个新节点的 span，随后清零
+// 重新累计，这与 Set 里 Redis zslInsert 风格的 span 计算是等价的。
+func (list *SkipList[K, V]) appendAscending(entries []snapshotEntry[K, V]) {
+	tails := make([]*elementNode[K, V], list.maxLevel)
+	for i := range tails {
+		tails[i] = &list.elementNode
+	}
+	levelCount := make([]int, list.maxLevel)
+
+	var last *Element[K, V]
+	for _, entry := range entries {
+		level := list.randLevel()
+		element := &Element[K, V]{
+			elementNode: elementNode[K, V]{
+				next: make([]*Element[K, V], level),
+				span: make([]int, level),
+			},
+			backward: last,
+			key:      entry.Key,
+			value:    entry.Value,
+		}
+
+		for i := range levelCount {
+			levelCount[i]++
+		}
+		for i := 0; i < level; i++ {
+			tails[i].span[i] = levelCount[i]
+			tails[i].next[i] = element
+			tails[i] = &element.elementNode
+			levelCount[i] = 0
+		}
+
+		list.length++
+		last = element
+	}
+
+	list.tail = last
+}
+
+// aofOp 标识一条 AOF 记录对应的操作。
+type aofOp byte
+
+const (
+	aofSet aofOp = iota
+	aofDel
+)
+
+// aofRecord 是单条 AOF 日志记录的落盘格式。
+type aofRecord[K any, V any] struct {
+	Op    aofOp
+	Key   K
+	Value V
+}
+
+// WithAOF 打开追加写日志模式：此后每次成功的 Set/Remove 都会向 w 写入一条
+// gob 编码的操作记录，崩溃重启的进程可以用 ReplayAOF 重放日志恢复状态。
+// 返回接收者本身以便链式调用，例如 NewSkipList().WithAOF(f)。
+func (list *SkipList[K, V]) WithAOF(w io.Writer) *SkipList[K, V] {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.aof = gob.NewEncoder(w)
+	return list
+}
+
+// logSet 在持有写锁的情况下记录一条 SET 操作；AOF 是尽力而为的旁路写入，
+// 编码失败不会影响 Set 本身的返回值。
+func (list *SkipList[K, V]) logSet(key K, value V) {
+	if list.aof == nil {
+		return
+	}
+	_ = list.aof.Encode(aofRecord[K, V]{Op: aofSet, Key: key, Value: value})
+}
+
+// logDel 在持有写锁的情况下记录一条 DEL 操作。
+func (list *SkipList[K, V]) logDel(key K) {
+	if list.aof == nil {
+		return
+	}
+	var zero V
+	_ = list.aof.Encode(aofRecord[K, V]{Op: aofDel, Key: key, Value: zero})
+}
+
+// ReplayAOF 重放 WithAOF 写出的日志，按记录顺序依次调用 Set/Remove，
+// 重建出一个与崩溃前等价的跳表。
+func ReplayAOF[K any, V any](r io.Reader, less func(a, b K) bool) (*SkipList[K, V], error) {
+	list := NewSkipListFunc[K, V](less)
+	dec := gob.NewDecoder(r)
+
+	for {
+		var rec aofRecord[K, V]
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch rec.Op {
+		case aofSet:
+			list.Set(rec.Key, rec.Value)
+		case aofDel:
+			list.Remove(rec.Key)
+		}
+	}
+
+	return list, nil
+}
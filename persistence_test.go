@@ -0,0 +1,83 @@
+package gocodebase
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSnapshotRestoreRoundTrip 验证 Snapshot 写出的数据可以被 Restore 精确重建：
+// 元素个数、顺序和各自的 key/value 都要与原跳表一致。
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	list := NewOrdered[float64, string]()
+	want := map[float64]string{1: "a", 2: "b", 3: "c", 10: "j", -5: "neg"}
+	for k, v := range want {
+		list.Set(k, v)
+	}
+
+	var buf bytes.Buffer
+	if err := list.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	restored, err := Restore[float64, string](&buf, func(a, b float64) bool { return a < b })
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	got := map[float64]string{}
+	var keys []float64
+	for e := restored.Front(); e != nil; e = e.Next() {
+		got[e.Key()] = e.Value()
+		keys = append(keys, e.Key())
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("restored %d entries, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("restored[%v] = %q, want %q", k, got[k], v)
+		}
+	}
+	for i := 0; i+1 < len(keys); i++ {
+		if keys[i] >= keys[i+1] {
+			t.Fatalf("restored keys are not in ascending order: %v", keys)
+		}
+	}
+}
+
+// TestAOFReplayRoundTrip 验证 WithAOF 记录的操作日志可以被 ReplayAOF 重放出
+// 与原跳表一致的状态，包括删除操作。
+func TestAOFReplayRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	list := NewOrdered[float64, string]().WithAOF(&buf)
+
+	list.Set(1, "a")
+	list.Set(2, "b")
+	list.Set(3, "c")
+	list.Set(2, "b-updated")
+	list.Remove(1)
+
+	replayed, err := ReplayAOF[float64, string](&buf, func(a, b float64) bool { return a < b })
+	if err != nil {
+		t.Fatalf("ReplayAOF failed: %v", err)
+	}
+
+	want := map[float64]string{2: "b-updated", 3: "c"}
+	got := map[float64]string{}
+	for e := replayed.Front(); e != nil; e = e.Next() {
+		got[e.Key()] = e.Value()
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("replayed %d entries, want %d (%v)", len(got), len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("replayed[%v] = %q, want %q", k, got[k], v)
+		}
+	}
+	if e := replayed.Get(1); e != nil {
+		t.Fatalf("key 1 should have been removed by the replayed DEL record")
+	}
+}
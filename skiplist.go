@@ -1,6 +1,8 @@
 package gocodebase
 
 import (
+	"cmp"
+	"encoding/gob"
 	"math"
 	"math/rand"
 	"sync"
@@ -14,42 +16,76 @@ const (
 )
 
 // elementNode 数组指针，指向元素
-type elementNode struct {
-	next []*Element
+type elementNode[K any, V any] struct {
+	next []*Element[K, V]
+	// span 与 next 等长；span[i] 是第 i 层 forward 指针跨越的 0 层节点数（计入目的节点本身）。
+	// 对应 Redis t_zset.c 的 zskiplistLevel.span，用于 Rank/ElementByRank 做 O(log n) 排名查询。
+	span []int
 }
 
 // Element 跳转表数据结构
-type Element struct {
-	elementNode
-	key   float64
-	value interface{} // 定义元素
+type Element[K any, V any] struct {
+	elementNode[K, V]
+	backward *Element[K, V] // 上一个元素，用于反向遍历
+	key      K
+	value    V
 }
 
 // Key 获取key的值
-func (e *Element) Key() float64 {
+func (e *Element[K, V]) Key() K {
 	return e.key
 }
 
 // Value 获取key的值
-func (e *Element) Value() interface{} {
+func (e *Element[K, V]) Value() V {
 	return e.value
 }
 
+// Next 返回顺序遍历中的下一个元素，到达末尾时返回 nil。
+//
+// Next 不加锁；并发写入会使正在使用的游标失效，调用方需要自行保证遍历期间没有并发写。
+func (e *Element[K, V]) Next() *Element[K, V] {
+	return e.next[0]
+}
+
+// Prev 返回顺序遍历中的上一个元素，到达开头时返回 nil。
+//
+// Prev 不加锁；并发写入会使正在使用的游标失效，调用方需要自行保证遍历期间没有并发写。
+func (e *Element[K, V]) Prev() *Element[K, V] {
+	return e.backward
+}
+
+// SkipList 是一个由 Less 比较器定义顺序的跳转表，K、V 可以是任意类型。
+type SkipList[K any, V any] struct {
+	elementNode[K, V]
+	tail        *Element[K, V]    // 最后一个元素，支持反向遍历
+	maxLevel    int               // 最大深度
+	length      int               // 长度
+	randSource  rand.Source       // 动态调节跳转表的长度
+	seed        int64             // 构造时配置的种子，写入快照；用 WithRandSource 时 randSource 并不由它生成
+	probability float64           // 概率
+	probTable   []float64         // 存储位置，对应key
+	mutex       sync.RWMutex      // 保证线程安全
+	less        func(a, b K) bool // key比较器
+	aof         *gob.Encoder      // 非 nil 时，Set/Remove 会追加写一条操作记录
+}
+
+// SkipListFloat64 是 key 为 float64 的跳转表，保留给旧调用方使用。
+type SkipListFloat64 = SkipList[float64, interface{}]
 
-type SkipList struct {
-	elementNode
-	maxLevel       int            // 最大深度
-	length         int            // 长度
-	randSource     rand.Source    // 动态调节跳转表的长度
-	probability    float64        // 概率
-	probTable      []float64      // 存储位置，对应key
-	mutex          sync.RWMutex   // 保证线程安全
-	prevNodesCache []*elementNode // 缓存
+// NewSkipList 新建跳转表，key 为 float64，与历史版本保持兼容。
+func NewSkipList() *SkipListFloat64 {
+	return NewOrdered[float64, interface{}]()
 }
 
-// NewSkipList 新建跳转表
-func NewSkipList() *SkipList {
-	return NewWithMaxLevel(DefaultMaxLevel)
+// NewOrdered 新建一个 key 为 cmp.Ordered 类型的跳转表，使用默认的小于比较。
+func NewOrdered[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFunc[K, V](func(a, b K) bool { return a < b })
+}
+
+// NewSkipListFunc 使用自定义比较器 less 新建跳转表，支持字符串、复合key等任意排序的key类型。
+func NewSkipListFunc[K any, V any](less func(a, b K) bool) *SkipList[K, V] {
+	return NewWithMaxLevelFunc[K, V](DefaultMaxLevel, less)
 }
 
 // ProbabilityTable 初始化 Probability Table
@@ -61,24 +97,18 @@ func ProbabilityTable(probability float64, maxLevel int) (table []float64) {
 	return table
 }
 
-// NewWithMaxLevel 自定义maxLevel新建跳转表
-func NewWithMaxLevel(maxLevel int) *SkipList {
-	if maxLevel < 1 || maxLevel > DefaultMaxLevel {
-		panic("invalid maxlevel")
-	}
+// NewWithMaxLevel 自定义maxLevel新建跳转表，key 为 float64，与历史版本保持兼容。
+func NewWithMaxLevel(maxLevel int) *SkipListFloat64 {
+	return NewWithMaxLevelFunc[float64, interface{}](maxLevel, func(a, b float64) bool { return a < b })
+}
 
-	return &SkipList{
-		elementNode:    elementNode{next: make([]*Element, maxLevel)},
-		prevNodesCache: make([]*elementNode, maxLevel),
-		maxLevel:       maxLevel,
-		randSource:     rand.New(rand.NewSource(42)),
-		probability:    DefaultProbability,
-		probTable:      ProbabilityTable(DefaultProbability, maxLevel),
-	}
+// NewWithMaxLevelFunc 使用自定义比较器 less 和 maxLevel 新建跳转表。
+func NewWithMaxLevelFunc[K any, V any](maxLevel int, less func(a, b K) bool) *SkipList[K, V] {
+	return NewWithOptions[K, V](less, WithMaxLevel(maxLevel))
 }
 
 // 随机计算最接近的
-func (list *SkipList) randLevel() (level int) {
+func (list *SkipList[K, V]) randLevel() (level int) {
 	r := float64(list.randSource.Int63()) / (1 << 63)
 	level = 1
 	for level < list.maxLevel && r < list.probTable[level] {
@@ -88,56 +118,85 @@ func (list *SkipList) randLevel() (level int) {
 	return level
 }
 
-// SetProbability 设置新的概率,刷新概率表
-func (list *SkipList) SetProbability(newProbability float64) {
+// SetProbability 设置新的概率,刷新概率表；newProbability 必须落在 (0, 1) 内。
+// 加写锁是因为 probTable 会被持锁中的 randLevel 并发读取，不加锁会在运行中的跳表上产生数据竞争。
+func (list *SkipList[K, V]) SetProbability(newProbability float64) {
+	if newProbability <= 0 || newProbability >= 1 {
+		panic("invalid probability")
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	list.probability = newProbability
 	list.probTable = ProbabilityTable(newProbability, list.maxLevel)
 }
 
 // Set 存储新的值
-func (list *SkipList) Set(key float64, value interface{}) *Element {
+func (list *SkipList[K, V]) Set(key K, value V) *Element[K, V] {
 	list.mutex.Lock()
 	defer list.mutex.Unlock() // 线程安全
 
-	var element *Element
-	prevs := list.getPrevElementNodes(key)
-	if element = prevs[0].next[0]; element != nil && key == element.key {
+	var element *Element[K, V]
+	prevs, ranks, prevElem := list.getPrevElementNodesWithRank(key)
+	if element = prevs[0].next[0]; element != nil && !list.less(key, element.key) && !list.less(element.key, key) {
 		element.value = value
+		list.logSet(key, value)
 		return element
 	}
 
-	element = &Element{
-		elementNode: elementNode{next: make([]*Element, list.randLevel())},
-		key:         key,
-		value:       value,
+	level := list.randLevel()
+	element = &Element[K, V]{
+		elementNode: elementNode[K, V]{
+			next: make([]*Element[K, V], level),
+			span: make([]int, level),
+		},
+		backward: prevElem,
+		key:      key,
+		value:    value,
 	}
 	list.length++
 
-	for i := range element.next { // 插入数据
+	// span 的计算照搬 Redis zslInsert：ranks[0]-ranks[i] 是从 prevs[i] 到 prevs[0] 之间
+	// 跨越的 0 层节点数，新节点在第 i 层的 span 因此是 prevs[i] 原有的 span 减去这段距离，
+	// 而 prevs[i] 自己的 span 则变成这段距离再加上新节点本身这一步。
+	for i := 0; i < level; i++ {
 		element.next[i] = prevs[i].next[i]
-		prevs[i].next[i] = element // 记录位置
+		element.span[i] = prevs[i].span[i] - (ranks[0] - ranks[i])
+		prevs[i].next[i] = element
+		prevs[i].span[i] = ranks[0] - ranks[i] + 1
+	}
+	for i := level; i < list.maxLevel; i++ {
+		prevs[i].span[i]++ // 新节点在这些层级之下被跨过，原有指针多跨了一个节点
 	}
 
+	if element.next[0] != nil {
+		element.next[0].backward = element
+	} else {
+		list.tail = element
+	}
+
+	list.logSet(key, value)
 	return element
 }
 
 // Get 获取key对应的值
-func (list *SkipList) Get(key float64) *Element {
+func (list *SkipList[K, V]) Get(key K) *Element[K, V] {
 	list.mutex.Lock()
 	defer list.mutex.Unlock() // 线程安全
 
-	var prev *elementNode = &list.elementNode // 保存前置结点
-	var next *Element
+	var prev *elementNode[K, V] = &list.elementNode // 保存前置结点
+	var next *Element[K, V]
 
 	for i := list.maxLevel - 1; i >= 0; i-- {
 		next = prev.next[i] // 循环跳到下一个
-		for next != nil && key > next.key {
+		for next != nil && list.less(next.key, key) {
 			prev = &next.elementNode
 			next = next.next[i]
 		}
 	}
 
-	if next != nil && next.key == key { // 找到
+	if next != nil && !list.less(key, next.key) { // 找到
 		return next
 	}
 
@@ -145,35 +204,227 @@ func (list *SkipList) Get(key float64) *Element {
 }
 
 // Remove 获取key对应的值
-func (list *SkipList) Remove(key float64) *Element {
+func (list *SkipList[K, V]) Remove(key K) *Element[K, V] {
 	list.mutex.Lock()
 	defer list.mutex.Unlock() // 线程安全
 
-	var element *Element
-	prevs := list.getPrevElementNodes(key)
-	if element = prevs[0].next[0]; element != nil && key == element.key {
-		for k, v := range element.next {
-			prevs[k].next[k] = v // 删除
+	var element *Element[K, V]
+	prevs, _ := list.getPrevElementNodes(key)
+	if element = prevs[0].next[0]; element != nil && !list.less(key, element.key) && !list.less(element.key, key) {
+		for i := range prevs {
+			if prevs[i].next[i] == element {
+				prevs[i].span[i] += element.span[i] - 1 // 直接跨过了被删除的节点，span 合并过去
+				prevs[i].next[i] = element.next[i]
+			} else {
+				prevs[i].span[i]-- // 这一层原本就跨过 element，少了一个节点
+			}
+		}
+
+		if element.next[0] != nil {
+			element.next[0].backward = element.backward
+		} else {
+			list.tail = element.backward
 		}
 
 		list.length--
+		list.logDel(key)
 		return element
 	}
 
 	return nil
 }
 
-func (list *SkipList) getPrevElementNodes(key float64) []*elementNode {
-	var prev *elementNode = &list.elementNode // 保存前置结点
-	var next *Element
-	prevs := list.prevNodesCache // 缓冲集合
+// getPrevElementNodes 返回每一层紧邻 key 之前的节点，以及真正的前驱元素
+// （哨兵头节点之前则为 nil），后者用于维护 backward 指针。
+//
+// prevElem 在每一层推进时都会更新，而不只是第 0 层：因为每个元素在它拥有的
+// 所有层都是同一个节点，descent 从高层走到低层时单调逼近 key，所以"整个下降
+// 过程中最后一次真正跨过的元素"就是第 0 层的前驱，即使第 0 层的内层循环一次
+// 都没有执行（前驱已经在更高层被跨过）。只在 i == 0 时赋值会在这种情况下遗漏。
+func (list *SkipList[K, V]) getPrevElementNodes(key K) ([]*elementNode[K, V], *Element[K, V]) {
+	var prev *elementNode[K, V] = &list.elementNode // 保存前置结点
+	var next *Element[K, V]
+	var prevElem *Element[K, V]
+	prevs := make([]*elementNode[K, V], list.maxLevel) // 每次调用独立分配，避免共享缓冲区造成数据竞争
 	for i := list.maxLevel - 1; i >= 0; i-- {
 		next = prev.next[i] // 循环跳到下一个
-		for next != nil && key > next.key {
+		for next != nil && list.less(next.key, key) {
+			prev = &next.elementNode
+			prevElem = next
+			next = next.next[i]
+		}
+		prevs[i] = prev
+	}
+	return prevs, prevElem
+}
+
+// getPrevElementNodesWithRank 和 getPrevElementNodes 类似，额外返回 ranks：
+// ranks[i] 是从头节点到 prevs[i] 经过的 0 层节点数，即 prevs[i] 的排名（头节点为 0）。
+// 只有 Set 需要它来按 Redis zslInsert 的方式计算新节点每一层的 span，其它只读路径
+// 不需要为此多付出一份累加开销，所以单独成一个函数而不是塞进 getPrevElementNodes。
+func (list *SkipList[K, V]) getPrevElementNodesWithRank(key K) (prevs []*elementNode[K, V], ranks []int, prevElem *Element[K, V]) {
+	prevs = make([]*elementNode[K, V], list.maxLevel)
+	ranks = make([]int, list.maxLevel)
+
+	prev := &list.elementNode
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		if i == list.maxLevel-1 {
+			ranks[i] = 0
+		} else {
+			ranks[i] = ranks[i+1]
+		}
+		next := prev.next[i]
+		for next != nil && list.less(next.key, key) {
+			ranks[i] += prev.span[i]
 			prev = &next.elementNode
+			prevElem = next
 			next = next.next[i]
 		}
 		prevs[i] = prev
 	}
-	return prevs
+	return prevs, ranks, prevElem
+}
+
+// Len 返回当前元素个数。
+func (list *SkipList[K, V]) Len() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
+}
+
+// Seek 返回升序遍历中第一个不小于 key 的元素，不存在这样的元素时返回 nil。
+// 用于只知道复合 key 一部分、要从某个下界开始扫描的场景（比如 zset 按 score
+// 查询时不关心 member），找到下界后配合 Next 继续按需遍历。
+func (list *SkipList[K, V]) Seek(key K) *Element[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var prev *elementNode[K, V] = &list.elementNode
+	var next *Element[K, V]
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next = prev.next[i]
+		for next != nil && list.less(next.key, key) {
+			prev = &next.elementNode
+			next = next.next[i]
+		}
+	}
+	return next
+}
+
+// Rank 返回 key 对应元素的排名（从 0 开始，升序），key 不存在时返回 (0, false)。
+// 基于 span 实现，时间复杂度 O(log n)，对应 Redis zslGetRank。
+func (list *SkipList[K, V]) Rank(key K) (int, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	rank := 0
+	prev := &list.elementNode
+	var next *Element[K, V]
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next = prev.next[i]
+		for next != nil && list.less(next.key, key) {
+			rank += prev.span[i]
+			prev = &next.elementNode
+			next = next.next[i]
+		}
+	}
+
+	if next != nil && !list.less(key, next.key) {
+		return rank, true
+	}
+	return 0, false
+}
+
+// elementByRank 是 ElementByRank 去掉加锁的版本，供已经持有读锁的方法
+// （如 RangeByRank）内部调用，避免 sync.RWMutex 不可重入导致的重复加锁。
+func (list *SkipList[K, V]) elementByRank(rank int) *Element[K, V] {
+	if rank < 0 {
+		return nil
+	}
+
+	target := rank + 1 // span 按 Redis 约定从 1 开始计数，这里换算成同一套 1-based 坐标
+	traversed := 0
+	x := &list.elementNode
+	var elem *Element[K, V]
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= target {
+			traversed += x.span[i]
+			elem = x.next[i]
+			x = &elem.elementNode
+		}
+	}
+	if traversed == target {
+		return elem
+	}
+	return nil
+}
+
+// ElementByRank 返回升序排名为 rank（从 0 开始）的元素，超出范围时返回 nil。
+// 基于 span 实现，时间复杂度 O(log n)，对应 Redis zslGetElementByRank。
+func (list *SkipList[K, V]) ElementByRank(rank int) *Element[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.elementByRank(rank)
+}
+
+// Front 返回升序遍历的第一个元素，列表为空时返回 nil。
+func (list *SkipList[K, V]) Front() *Element[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.next[0]
+}
+
+// Back 返回升序遍历的最后一个元素，列表为空时返回 nil。
+func (list *SkipList[K, V]) Back() *Element[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.tail
+}
+
+// Range 按升序遍历 key 落在 [minKey, maxKey] 区间内的元素，对每个元素调用 fn；
+// fn 返回 false 时提前终止遍历。遍历期间持有读锁，不会阻塞其它读者。
+func (list *SkipList[K, V]) Range(minKey, maxKey K, fn func(*Element[K, V]) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var prev *elementNode[K, V] = &list.elementNode
+	var next *Element[K, V]
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next = prev.next[i]
+		for next != nil && list.less(next.key, minKey) {
+			prev = &next.elementNode
+			next = next.next[i]
+		}
+	}
+
+	for next != nil && !list.less(maxKey, next.key) {
+		if !fn(next) {
+			return
+		}
+		next = next.next[0]
+	}
+}
+
+// RangeByRank 按升序遍历排名区间 [startRank, endRank)（从 0 开始，左闭右开）内的元素，
+// 对每个元素调用 fn；fn 返回 false 时提前终止遍历。起点通过 span 以 O(log n) 定位，
+// 区间内的遍历仍是 O(k)。
+func (list *SkipList[K, V]) RangeByRank(startRank, endRank int, fn func(*Element[K, V]) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if startRank < 0 {
+		startRank = 0
+	}
+
+	element := list.elementByRank(startRank)
+	for rank := startRank; rank < endRank && element != nil; rank++ {
+		if !fn(element) {
+			return
+		}
+		element = element.next[0]
+	}
 }
@@ -0,0 +1,176 @@
+package gocodebase
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestCursorBackwardTraversal 复现过 backward 指针只在第 0 层内层循环体里赋值、
+// 导致前驱在更高层就已经跨过时被遗漏的问题：依次插入 36、30、11、10、35，
+// 从 Back() 反向走一遍应当恰好访问全部 5 个元素。
+func TestCursorBackwardTraversal(t *testing.T) {
+	list := NewOrderedWithOptions[int, int](WithSeed(0))
+	for _, key := range []int{36, 30, 11, 10, 35} {
+		list.Set(key, key)
+	}
+
+	var got []int
+	for e := list.Back(); e != nil; e = e.Prev() {
+		got = append(got, e.Key())
+	}
+
+	if len(got) != 5 {
+		t.Fatalf("Back()/Prev() visited %d elements, want 5 (visited keys: %v)", len(got), got)
+	}
+	for i := 0; i+1 < len(got); i++ {
+		if got[i] <= got[i+1] {
+			t.Fatalf("Prev() must walk in descending order, got %v", got)
+		}
+	}
+}
+
+// TestFrontBackRoundTrip 用随机 key 验证 Front()/Next() 按升序访问全部元素，
+// 且 Back()/Prev() 的遍历结果恰好是升序遍历的逆序。
+func TestFrontBackRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := NewOrderedWithOptions[int, int](WithSeed(2))
+
+	want := map[int]bool{}
+	for len(want) < 100 {
+		want[r.Intn(100000)] = true
+	}
+	for key := range want {
+		list.Set(key, key*2)
+	}
+
+	var forward []int
+	for e := list.Front(); e != nil; e = e.Next() {
+		forward = append(forward, e.Key())
+	}
+	if len(forward) != len(want) {
+		t.Fatalf("forward traversal visited %d elements, want %d", len(forward), len(want))
+	}
+	for i := 0; i+1 < len(forward); i++ {
+		if forward[i] >= forward[i+1] {
+			t.Fatalf("Next() must walk in ascending order, got %v at index %d", forward, i)
+		}
+	}
+
+	var backward []int
+	for e := list.Back(); e != nil; e = e.Prev() {
+		backward = append(backward, e.Key())
+	}
+	if len(backward) != len(forward) {
+		t.Fatalf("backward traversal visited %d elements, want %d", len(backward), len(forward))
+	}
+	for i, key := range backward {
+		if key != forward[len(forward)-1-i] {
+			t.Fatalf("backward traversal is not the reverse of forward traversal at index %d: got %d, want %d", i, key, forward[len(forward)-1-i])
+		}
+	}
+}
+
+// TestRange 验证 Range 只访问落在 [min, max] 区间内的元素，且按升序访问。
+func TestRange(t *testing.T) {
+	list := NewOrderedWithOptions[int, int](WithSeed(3))
+	for i := 0; i < 20; i++ {
+		list.Set(i, i)
+	}
+
+	var got []int
+	list.Range(5, 10, func(e *Element[int, int]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+
+	want := []int{5, 6, 7, 8, 9, 10}
+	if len(got) != len(want) {
+		t.Fatalf("Range visited %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range visited %v, want %v", got, want)
+		}
+	}
+}
+
+// TestRangeByRank 验证 RangeByRank 按 [startRank, endRank) 的排名区间访问元素。
+func TestRangeByRank(t *testing.T) {
+	list := NewOrderedWithOptions[int, int](WithSeed(4))
+	for i := 0; i < 10; i++ {
+		list.Set(i, i)
+	}
+
+	var got []int
+	list.RangeByRank(2, 5, func(e *Element[int, int]) bool {
+		got = append(got, e.Key())
+		return true
+	})
+
+	want := []int{2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("RangeByRank visited %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("RangeByRank visited %v, want %v", got, want)
+		}
+	}
+}
+
+// TestRankAndElementByRank 验证 Rank 与 ElementByRank 互为反函数，且与 Front()/Next()
+// 数出来的朴素排名一致，覆盖 span 在插入时的计算。
+func TestRankAndElementByRank(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	list := NewOrderedWithOptions[int, int](WithSeed(6))
+
+	keys := map[int]bool{}
+	for len(keys) < 50 {
+		keys[r.Intn(10000)] = true
+	}
+	for key := range keys {
+		list.Set(key, key)
+	}
+
+	var wantKeys []int
+	for e := list.Front(); e != nil; e = e.Next() {
+		wantKeys = append(wantKeys, e.Key())
+	}
+
+	for wantRank, key := range wantKeys {
+		rank, ok := list.Rank(key)
+		if !ok || rank != wantRank {
+			t.Fatalf("Rank(%d) = (%d, %v), want (%d, true)", key, rank, ok, wantRank)
+		}
+
+		element := list.ElementByRank(wantRank)
+		if element == nil || element.Key() != key {
+			t.Fatalf("ElementByRank(%d) = %v, want key %d", wantRank, element, key)
+		}
+	}
+
+	if _, ok := list.Rank(-1); ok {
+		t.Fatalf("Rank(-1) should not be found")
+	}
+	if e := list.ElementByRank(len(wantKeys)); e != nil {
+		t.Fatalf("ElementByRank(%d) out of range should return nil, got %v", len(wantKeys), e)
+	}
+}
+
+// TestSeek 验证 Seek 返回升序遍历中第一个不小于 key 的元素。
+func TestSeek(t *testing.T) {
+	list := NewOrderedWithOptions[int, int](WithSeed(7))
+	for _, key := range []int{10, 20, 30, 40} {
+		list.Set(key, key)
+	}
+
+	if e := list.Seek(25); e == nil || e.Key() != 30 {
+		t.Fatalf("Seek(25) = %v, want key 30", e)
+	}
+	if e := list.Seek(20); e == nil || e.Key() != 20 {
+		t.Fatalf("Seek(20) = %v, want key 20", e)
+	}
+	if e := list.Seek(41); e != nil {
+		t.Fatalf("Seek(41) = %v, want nil", e)
+	}
+}
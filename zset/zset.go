@@ -0,0 +1,195 @@
+// Package zset 在 SkipList 之上实现 Redis 风格的有序集合(ZSET)语义:
+// 按 score 排序,同时支持 O(log n) 的按名查找、按排名查询和按 score 范围查询。
+package zset
+
+import (
+	"math"
+	"sync"
+
+	gocodebase "github.com/hehaoyulkeke/gocodebase"
+)
+
+// zsetKey 是 SortedSet 在共享的 SkipList 引擎里使用的复合 key:
+// 先按 score 排序,score 相同再按 member 排序打破平局,
+// 对应 Redis t_zset.c 用 (score, member) 排序元素的约定。
+type zsetKey struct {
+	score  float64
+	member string
+}
+
+// lessZsetKey 是 zsetKey 的比较器,传给 SkipList 作为排序依据。
+func lessZsetKey(a, b zsetKey) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.member < b.member
+}
+
+// SortedSet 是基于 SkipList[zsetKey, struct{}] 实现的 Redis 风格有序集合:
+// 成员按 (score, member) 排序,同时维护 member -> score 的哈希索引以支持 O(1) 的 ZScore 查询。
+//
+// dict 和 list 合起来才是一份完整状态(ZAdd/ZRemRangeByRank 要同时改两者),
+// 所以并发安全由 SortedSet 自己的 mu 保证,而不是依赖内部 list 自带的锁:
+// 光靠 list 的锁只能保证单次 list 操作原子,保护不了"读 dict 再据此改 list"这类组合操作。
+type SortedSet struct {
+	mu   sync.RWMutex
+	dict map[string]float64
+	list *gocodebase.SkipList[zsetKey, struct{}]
+}
+
+// NewSortedSet 新建一个空的有序集合。
+func NewSortedSet() *SortedSet {
+	return &SortedSet{
+		dict: make(map[string]float64),
+		list: gocodebase.NewSkipListFunc[zsetKey, struct{}](lessZsetKey),
+	}
+}
+
+// ZAdd 新增或更新 member 的 score,返回是否为新成员。
+func (z *SortedSet) ZAdd(member string, score float64) bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	if oldScore, ok := z.dict[member]; ok {
+		if oldScore != score {
+			z.list.Remove(zsetKey{score: oldScore, member: member})
+			z.list.Set(zsetKey{score: score, member: member}, struct{}{})
+		}
+		z.dict[member] = score
+		return false
+	}
+
+	z.dict[member] = score
+	z.list.Set(zsetKey{score: score, member: member}, struct{}{})
+	return true
+}
+
+// ZScore 返回 member 的 score。
+func (z *SortedSet) ZScore(member string) (float64, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	score, ok := z.dict[member]
+	return score, ok
+}
+
+// rank 是 ZRank 去掉加锁的版本,供已经持有锁的方法(如 ZRevRank)内部调用,
+// 避免 sync.RWMutex 不可重入导致的重复加锁。
+func (z *SortedSet) rank(member string) (int, bool) {
+	score, ok := z.dict[member]
+	if !ok {
+		return 0, false
+	}
+	return z.list.Rank(zsetKey{score: score, member: member})
+}
+
+// ZRank 返回 member 的升序排名(从 0 开始)。
+func (z *SortedSet) ZRank(member string) (int, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.rank(member)
+}
+
+// ZRevRank 返回 member 的降序排名(从 0 开始)。
+func (z *SortedSet) ZRevRank(member string) (int, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	rank, ok := z.rank(member)
+	if !ok {
+		return 0, false
+	}
+	return z.list.Len() - 1 - rank, true
+}
+
+// ZRange 返回升序排名区间 [start, stop] 内的成员,支持 Redis 风格的负数下标。
+func (z *SortedSet) ZRange(start, stop int) []string {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	length := z.list.Len()
+	start, stop, ok := normalizeRange(start, stop, length)
+	if !ok {
+		return nil
+	}
+
+	element := z.list.ElementByRank(start)
+	result := make([]string, 0, stop-start+1)
+	for i := start; i <= stop && element != nil; i++ {
+		result = append(result, element.Key().member)
+		element = element.Next()
+	}
+	return result
+}
+
+// ZRangeByScore 返回 score 落在 [min, max] 区间内的成员,按 score 升序排列。
+func (z *SortedSet) ZRangeByScore(min, max float64) []string {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	var result []string
+
+	// member 的下界取空字符串:空字符串是最小的 member,所以 {min, ""} 必然不大于
+	// score 等于 min 的任何条目(包括 member 为空的条目),可以当成 Seek 的下界来用。
+	for e := z.list.Seek(zsetKey{score: min, member: ""}); e != nil && e.Key().score <= max; e = e.Next() {
+		result = append(result, e.Key().member)
+	}
+
+	return result
+}
+
+// ZRemRangeByRank 删除升序排名区间 [start, stop] 内的成员,返回删除数量。
+func (z *SortedSet) ZRemRangeByRank(start, stop int) int {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	length := z.list.Len()
+	start, stop, ok := normalizeRange(start, stop, length)
+	if !ok {
+		return 0
+	}
+
+	element := z.list.ElementByRank(start)
+	keys := make([]zsetKey, 0, stop-start+1)
+	for i := start; i <= stop && element != nil; i++ {
+		keys = append(keys, element.Key())
+		element = element.Next()
+	}
+
+	for _, key := range keys {
+		z.list.Remove(key)
+		delete(z.dict, key.member)
+	}
+
+	return len(keys)
+}
+
+// Len 返回有序集合的成员数量。
+func (z *SortedSet) Len() int {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+
+	return z.list.Len()
+}
+
+// normalizeRange 将可能带负数的 [start, stop] 下标规范为合法的闭区间,
+// 参照 Redis ZRANGE 的语义: 负数表示从末尾倒数,越界则截断。
+func normalizeRange(start, stop, length int) (int, int, bool) {
+	if length == 0 {
+		return 0, 0, false
+	}
+	if start < 0 {
+		start = int(math.Max(float64(length+start), 0))
+	}
+	if stop < 0 {
+		stop = length + stop
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if start > stop || start >= length {
+		return 0, 0, false
+	}
+	return start, stop, true
+}
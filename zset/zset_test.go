@@ -0,0 +1,128 @@
+package zset
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// TestSortedSetBasic 验证 ZAdd/ZScore/ZRank/ZRange 在共享 SkipList 引擎上的基本语义：
+// 按 score 升序排名，score 相同按 member 打破平局。
+func TestSortedSetBasic(t *testing.T) {
+	z := NewSortedSet()
+	z.ZAdd("alice", 10)
+	z.ZAdd("bob", 20)
+	z.ZAdd("carol", 10)
+
+	if score, ok := z.ZScore("bob"); !ok || score != 20 {
+		t.Fatalf("ZScore(bob) = (%v, %v), want (20, true)", score, ok)
+	}
+
+	// score 相同时按 member 排序：alice < carol。
+	want := []string{"alice", "carol", "bob"}
+	got := z.ZRange(0, -1)
+	if len(got) != len(want) {
+		t.Fatalf("ZRange(0, -1) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ZRange(0, -1) = %v, want %v", got, want)
+		}
+	}
+
+	if rank, ok := z.ZRank("bob"); !ok || rank != 2 {
+		t.Fatalf("ZRank(bob) = (%d, %v), want (2, true)", rank, ok)
+	}
+	if rank, ok := z.ZRevRank("bob"); !ok || rank != 0 {
+		t.Fatalf("ZRevRank(bob) = (%d, %v), want (0, true)", rank, ok)
+	}
+	if _, ok := z.ZRank("dave"); ok {
+		t.Fatalf("ZRank(dave) should not be found")
+	}
+}
+
+// TestSortedSetUpdateScore 验证重复 ZAdd 同一 member 不同 score 时会更新排名，
+// 且不会在共享引擎里留下旧 key 的残留节点。
+func TestSortedSetUpdateScore(t *testing.T) {
+	z := NewSortedSet()
+	z.ZAdd("alice", 1)
+	z.ZAdd("bob", 2)
+
+	if isNew := z.ZAdd("alice", 5); isNew {
+		t.Fatalf("ZAdd on existing member should return false")
+	}
+
+	want := []string{"bob", "alice"}
+	got := z.ZRange(0, -1)
+	if len(got) != len(want) {
+		t.Fatalf("ZRange(0, -1) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ZRange(0, -1) = %v, want %v", got, want)
+		}
+	}
+	if z.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", z.Len())
+	}
+}
+
+// TestSortedSetRangeByScoreAndRemove 验证 ZRangeByScore 只按 score 过滤，
+// 以及 ZRemRangeByRank 删除排名区间内的成员并同步更新 dict。
+func TestSortedSetRangeByScoreAndRemove(t *testing.T) {
+	z := NewSortedSet()
+	for i, member := range []string{"a", "b", "c", "d", "e"} {
+		z.ZAdd(member, float64(i*10))
+	}
+
+	got := z.ZRangeByScore(10, 30)
+	want := []string{"b", "c", "d"}
+	if len(got) != len(want) {
+		t.Fatalf("ZRangeByScore(10, 30) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ZRangeByScore(10, 30) = %v, want %v", got, want)
+		}
+	}
+
+	if n := z.ZRemRangeByRank(0, 1); n != 2 {
+		t.Fatalf("ZRemRangeByRank(0, 1) = %d, want 2", n)
+	}
+	if z.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", z.Len())
+	}
+	if _, ok := z.ZScore("a"); ok {
+		t.Fatalf("ZScore(a) should be gone after ZRemRangeByRank")
+	}
+}
+
+// TestSortedSetConcurrentZAdd 用 go test -race 跑并发 ZAdd/ZRank/ZScore，
+// 验证 dict 和底层 list 由同一把锁保护，不会出现并发 map 写入的 panic 或数据竞争。
+func TestSortedSetConcurrentZAdd(t *testing.T) {
+	z := NewSortedSet()
+
+	const n = 200
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			z.ZAdd("m"+strconv.Itoa(i), float64(i))
+		}(i)
+	}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			member := "m" + strconv.Itoa(i)
+			z.ZScore(member)
+			z.ZRank(member)
+		}(i)
+	}
+	wg.Wait()
+
+	if z.Len() != n {
+		t.Fatalf("Len() = %d, want %d", z.Len(), n)
+	}
+}